@@ -0,0 +1,94 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"rag-searchbot-backend/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+)
+
+// Handler exposes operator-facing introspection over recent logs and
+// asynq's queues.
+type Handler struct {
+	inspector *asynq.Inspector
+}
+
+func NewHandler(inspector *asynq.Inspector) *Handler {
+	return &Handler{inspector: inspector}
+}
+
+// Logs handles GET /admin/logs, returning recent entries from the in-memory
+// ring buffer. ?limit caps how many are returned (default 200).
+func (h *Handler) Logs(c *gin.Context) {
+	limit := 200
+	if v := c.Query("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			limit = n
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"logs": logger.Recent(limit)})
+}
+
+type queueSnapshot struct {
+	Queue    string            `json:"queue"`
+	Pending  []*asynq.TaskInfo `json:"pending"`
+	Active   []*asynq.TaskInfo `json:"active"`
+	Retry    []*asynq.TaskInfo `json:"retry"`
+	Archived []*asynq.TaskInfo `json:"archived"`
+}
+
+// Jobs handles GET /admin/jobs, listing pending/active/retry/archived (dead)
+// tasks per queue.
+func (h *Handler) Jobs(c *gin.Context) {
+	queues, err := h.inspector.Queues()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	snapshots := make([]queueSnapshot, 0, len(queues))
+	for _, q := range queues {
+		pending, _ := h.inspector.ListPendingTasks(q)
+		active, _ := h.inspector.ListActiveTasks(q)
+		retry, _ := h.inspector.ListRetryTasks(q)
+		archived, _ := h.inspector.ListArchivedTasks(q)
+		snapshots = append(snapshots, queueSnapshot{
+			Queue:    q,
+			Pending:  pending,
+			Active:   active,
+			Retry:    retry,
+			Archived: archived,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"queues": snapshots})
+}
+
+// RequeueJob handles POST /admin/jobs/:queue/:id/requeue, moving a retry or
+// archived task back to pending so it runs again immediately.
+func (h *Handler) RequeueJob(c *gin.Context) {
+	queue, id := c.Param("queue"), c.Param("id")
+	if err := h.inspector.RunTask(queue, id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "requeued"})
+}
+
+// CancelJob handles DELETE /admin/jobs/:queue/:id. Tasks still in the queue
+// are deleted outright; a task already running is signaled to stop instead.
+func (h *Handler) CancelJob(c *gin.Context) {
+	queue, id := c.Param("queue"), c.Param("id")
+
+	if err := h.inspector.DeleteTask(queue, id); err != nil {
+		if err := h.inspector.CancelProcessing(id); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "cancelled"})
+}
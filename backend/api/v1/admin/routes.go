@@ -0,0 +1,39 @@
+package admin
+
+import (
+	"net/http"
+
+	"rag-searchbot-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+)
+
+// RegisterRoutes wires the operator-facing introspection endpoints: recent
+// log history and a view into asynq's queues, so operators can see why a
+// job (e.g. an embedding run) is stuck without shelling into the box. Every
+// route is admin-gated. authMiddleware must run first to populate "user";
+// requireAdmin only checks what's already there.
+func RegisterRoutes(apiGroup *gin.RouterGroup, inspector *asynq.Inspector, authMiddleware gin.HandlerFunc) {
+	h := NewHandler(inspector)
+
+	group := apiGroup.Group("/admin")
+	group.Use(authMiddleware, requireAdmin)
+	{
+		group.GET("/logs", h.Logs)
+		group.GET("/jobs", h.Jobs)
+		group.POST("/jobs/:queue/:id/requeue", h.RequeueJob)
+		group.DELETE("/jobs/:queue/:id", h.CancelJob)
+	}
+}
+
+// requireAdmin rejects any request whose authenticated user isn't an admin.
+// It runs after whatever middleware sets "user" in the context.
+func requireAdmin(c *gin.Context) {
+	userData, ok := c.MustGet("user").(*models.User)
+	if !ok || !userData.IsAdmin {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin access required"})
+		return
+	}
+	c.Next()
+}
@@ -0,0 +1,138 @@
+package ai
+
+import (
+	"net/http"
+
+	aiInternal "rag-searchbot-backend/internal/ai"
+	"rag-searchbot-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Handler struct {
+	service *aiInternal.AIService
+}
+
+func NewHandler(service *aiInternal.AIService) *Handler {
+	return &Handler{service: service}
+}
+
+func currentUser(c *gin.Context) (*models.User, bool) {
+	userData, ok := c.MustGet("user").(*models.User)
+	return userData, ok
+}
+
+// ListConversations handles GET /ai/conversations?post_id=...
+func (h *Handler) ListConversations(c *gin.Context) {
+	userData, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	postID, err := uuid.Parse(c.Query("post_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or missing post_id"})
+		return
+	}
+
+	conversations, err := h.service.ConvRepo.ListByPostAndUser(postID, userData.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, conversations)
+}
+
+// GetConversation handles GET /ai/conversations/:id, returning the
+// conversation with its full message history.
+func (h *Handler) GetConversation(c *gin.Context) {
+	userData, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid conversation id"})
+		return
+	}
+
+	conversation, err := h.service.ConvRepo.GetByIDForUser(id, userData.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if conversation == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "conversation not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, conversation)
+}
+
+type renameConversationRequest struct {
+	Title string `json:"title" binding:"required"`
+}
+
+// RenameConversation handles PATCH /ai/conversations/:id.
+func (h *Handler) RenameConversation(c *gin.Context) {
+	userData, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid conversation id"})
+		return
+	}
+
+	var req renameConversationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.ConvRepo.Rename(id, userData.ID, req.Title); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "conversation not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// DeleteConversation handles DELETE /ai/conversations/:id.
+func (h *Handler) DeleteConversation(c *gin.Context) {
+	userData, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid conversation id"})
+		return
+	}
+
+	if err := h.service.ConvRepo.Delete(id, userData.ID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "conversation not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
@@ -0,0 +1,26 @@
+package ai
+
+import (
+	aiInternal "rag-searchbot-backend/internal/ai"
+	"rag-searchbot-backend/internal/container"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+)
+
+// RegisterRoutes wires the AI endpoints onto apiGroup and the embedding
+// worker's completion handler onto mux. authMiddleware populates "user" in
+// the context; every conversation endpoint is scoped to the authenticated
+// caller.
+func RegisterRoutes(apiGroup *gin.RouterGroup, containerDI *container.Container, mux *asynq.ServeMux, authMiddleware gin.HandlerFunc) {
+	h := NewHandler(containerDI.AIService)
+
+	mux.HandleFunc(aiInternal.TaskTypeEmbeddingGenerated, aiInternal.HandleEmbeddingGenerated)
+
+	conversations := apiGroup.Group("/ai/conversations")
+	conversations.Use(authMiddleware)
+	conversations.GET("", h.ListConversations)
+	conversations.GET("/:id", h.GetConversation)
+	conversations.PATCH("/:id", h.RenameConversation)
+	conversations.DELETE("/:id", h.DeleteConversation)
+}
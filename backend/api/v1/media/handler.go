@@ -0,0 +1,106 @@
+package media
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	mediaInternal "rag-searchbot-backend/internal/media"
+	"rag-searchbot-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service *mediaInternal.MediaService
+}
+
+func NewHandler(service *mediaInternal.MediaService) *Handler {
+	return &Handler{service: service}
+}
+
+type presignRequest struct {
+	Key         string `json:"key" binding:"required"`
+	ContentType string `json:"content_type" binding:"required"`
+}
+
+type presignResponse struct {
+	URL     string              `json:"url"`
+	Headers map[string]string   `json:"headers"`
+	Media   mediaInternal.Media `json:"media"`
+}
+
+// Presign handles POST /media/presign: it returns the presigned upload URL,
+// the headers the client must send, and a media row in a pending state that
+// FinalizeUpload flips to ready once the client callback fires.
+func (h *Handler) Presign(c *gin.Context) {
+	var req presignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userData, ok := c.MustGet("user").(*models.User)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	media, url, headers, err := h.service.PresignUpload(userData.ID, req.Key, req.ContentType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presignResponse{URL: url, Headers: headers, Media: *media})
+}
+
+type finalizeRequest struct {
+	Key  string `json:"key" binding:"required"`
+	Size int64  `json:"size" binding:"required"`
+}
+
+// Finalize handles POST /media/finalize, the client callback invoked once
+// the direct upload to object storage completes.
+func (h *Handler) Finalize(c *gin.Context) {
+	var req finalizeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userData, ok := c.MustGet("user").(*models.User)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	media, err := h.service.FinalizeUpload(userData.ID, req.Key, req.Size)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, media)
+}
+
+// LocalUpload handles PUT /media/local-upload/*key, the direct-PUT target
+// LocalStore's PresignedPutURL hands back when the local driver is
+// configured. It authenticates via the signed exp/sig query params rather
+// than the usual bearer auth, since the client PUTs here directly.
+func (h *Handler) LocalUpload(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	exp, err := strconv.ParseInt(c.Query("exp"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or missing exp"})
+		return
+	}
+
+	if err := h.service.ReceiveLocalUpload(c.Request.Context(), key, exp, c.Query("sig"), c.Request.Body, c.Request.ContentLength, c.ContentType()); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
@@ -0,0 +1,25 @@
+package media
+
+import (
+	"rag-searchbot-backend/internal/container"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes wires the media endpoints onto apiGroup. authMiddleware
+// populates "user" in the context; presign/finalize both key off the
+// authenticated caller's id.
+func RegisterRoutes(apiGroup *gin.RouterGroup, containerDI *container.Container, authMiddleware gin.HandlerFunc) {
+	h := NewHandler(containerDI.MediaService)
+
+	mediaGroup := apiGroup.Group("/media")
+	mediaGroup.Use(authMiddleware)
+	mediaGroup.POST("/presign", h.Presign)
+	mediaGroup.POST("/finalize", h.Finalize)
+
+	// local-upload is the direct-PUT target LocalStore's PresignedPutURL
+	// hands back; it authenticates via the signed exp/sig query params
+	// baked into that URL, not the bearer auth middleware, since it's the
+	// client's raw object PUT rather than an API call on the user's behalf.
+	apiGroup.PUT("/media/local-upload/*key", h.LocalUpload)
+}
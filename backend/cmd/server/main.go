@@ -1,8 +1,12 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"rag-searchbot-backend/api/v1/admin"
 	"rag-searchbot-backend/api/v1/ai"
 	"rag-searchbot-backend/api/v1/auth"
 	"rag-searchbot-backend/api/v1/media"
@@ -16,6 +20,7 @@ import (
 	mediaInternal "rag-searchbot-backend/internal/media"
 	"rag-searchbot-backend/pkg/logger"
 	"strings"
+	"syscall"
 	"time"
 
 	"go.uber.org/zap"
@@ -27,6 +32,11 @@ import (
 	"gorm.io/gorm"
 )
 
+// shutdownGracePeriod bounds how long shutdown waits for in-flight HTTP
+// requests and the running cron job to finish. asynqServer.Shutdown is not
+// bounded by it; it waits for active tasks under asynq's own ShutdownTimeout.
+const shutdownGracePeriod = 15 * time.Second
+
 // Cron expression format explanation:
 // "0 0 0 * * *"
 //
@@ -36,16 +46,25 @@ import (
 //	| | | +------- Day of Month (1-31)
 //	| | +--------- Hour (0-23)
 //	| +----------- Minute (0-59)
-func StartMediaCleanupCron(db *gorm.DB, cache *cache.Service, logger *zap.Logger) {
+
+// StartMediaCleanupCron returns the running *cron.Cron so the caller can stop
+// it during shutdown; ctx is threaded into every DeleteUnusedImages run so a
+// sweep in progress aborts instead of outliving the process.
+func StartMediaCleanupCron(ctx context.Context, cfg *config.Config, db *gorm.DB, cache *cache.Service, logger *zap.Logger) *cron.Cron {
+	objectStore, err := mediaInternal.NewObjectStore(cfg.MediaStorage)
+	if err != nil {
+		logger.Fatal("[Startup] Failed to initialize media object store", zap.Error(err))
+	}
+
 	repo := mediaInternal.NewMediaRepository(db)
-	service := mediaInternal.NewMediaService(repo, logger)
+	service := mediaInternal.NewMediaService(repo, objectStore, logger)
 
 	c := cron.New(cron.WithSeconds())
 
 	// เรียกตอนเริ่ม server ทันที
 	go func() {
 		logger.Info("[Startup] Starting to delete unused images...")
-		err := service.DeleteUnusedImages()
+		err := service.DeleteUnusedImages(ctx)
 		if err != nil {
 			logger.Error("[Startup] Fail to deleting image", zap.Error(err))
 		} else {
@@ -54,9 +73,9 @@ func StartMediaCleanupCron(db *gorm.DB, cache *cache.Service, logger *zap.Logger
 	}()
 
 	// ตั้ง Cron ให้ลบทุกเที่ยงคืน
-	_, err := c.AddFunc("0 0 0 * * *", func() {
+	_, err = c.AddFunc("0 0 0 * * *", func() {
 		logger.Info("[Cron] Starting to delete unused images...")
-		err := service.DeleteUnusedImages()
+		err := service.DeleteUnusedImages(ctx)
 		if err != nil {
 			logger.Error("[Cron] Failed to delete unused images", zap.Error(err))
 		} else {
@@ -71,9 +90,12 @@ func StartMediaCleanupCron(db *gorm.DB, cache *cache.Service, logger *zap.Logger
 	}
 
 	c.Start()
+	return c
 }
 
 func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
 	cfg := config.LoadConfig()
 
@@ -125,19 +147,22 @@ func main() {
 		asynq.Config{Concurrency: 10},
 	)
 
+	asynqInspector := asynq.NewInspector(asynq.RedisClientOpt{Addr: cfg.RedisAddr})
+
 	mux := asynq.NewServeMux()
 
+	workerErrCh := make(chan error, 1)
 	go func() {
 		if err := asynqServer.Run(mux); err != nil {
-			logger.Log.Fatal("Worker error", zap.Error(err))
+			workerErrCh <- err
 		}
 	}()
 
 	logger.Log.Info("Cache service initialized successfully")
 
-	StartMediaCleanupCron(db, cacheService, logger.Log)
+	mediaCron := StartMediaCleanupCron(ctx, &cfg, db, cacheService, logger.Log)
 
-	containerDI, err := container.InitializeContainer(&cfg, db, logger.Log, redisClient, 24*time.Hour, asynqClient)
+	containerDI, err := container.InitializeContainer(ctx, &cfg, db, logger.Log, redisClient, 24*time.Hour, asynqClient)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -175,10 +200,53 @@ func main() {
 	ws.StartWebSocketServer(apiGroup, containerDI)
 	auth.RegisterRoutes(apiGroup, containerDI)
 	post.RegisterRoutes(apiGroup, containerDI, mux)
-	media.RegisterRoutes(apiGroup, containerDI)
+	media.RegisterRoutes(apiGroup, containerDI, auth.AuthMiddleware())
 	user.RegisterRoutes(apiGroup, containerDI)
-	ai.RegisterRoutes(apiGroup, containerDI, mux)
+	ai.RegisterRoutes(apiGroup, containerDI, mux, auth.AuthMiddleware())
 	notification.RegisterRoutes(apiGroup, containerDI)
+	admin.RegisterRoutes(apiGroup, asynqInspector, auth.AuthMiddleware())
+
+	srv := &http.Server{
+		Addr:    ":8088",
+		Handler: r,
+	}
+
+	go func() {
+		logger.Log.Info("Server listening", zap.String("addr", srv.Addr))
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Log.Fatal("Server failed", zap.Error(err))
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		logger.Log.Info("Shutdown signal received")
+	case err := <-workerErrCh:
+		logger.Log.Error("Asynq worker error, shutting down", zap.Error(err))
+		stop()
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownGracePeriod)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Log.Error("HTTP server shutdown error", zap.Error(err))
+	}
+
+	select {
+	case <-mediaCron.Stop().Done():
+		logger.Log.Info("Media cleanup cron stopped")
+	case <-shutdownCtx.Done():
+		logger.Log.Warn("Timed out waiting for media cleanup cron to stop")
+	}
+
+	asynqServer.Shutdown()
+	asynqClient.Close()
+	asynqInspector.Close()
+
+	if err := redisClient.Close(); err != nil {
+		logger.Log.Error("Redis client close error", zap.Error(err))
+	}
 
-	r.Run(":8088")
+	logger.Log.Info("Shutdown complete")
 }
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// MediaStorageConfig selects and configures the object storage backend used
+// by the media subsystem (see internal/media).
+type MediaStorageConfig struct {
+	// Driver is one of: "local", "s3", "minio", "cos", "oss".
+	Driver string
+
+	Local LocalStorageConfig
+	S3    S3StorageConfig
+	MinIO MinIOStorageConfig
+	COS   COSStorageConfig
+	OSS   OSSStorageConfig
+}
+
+type LocalStorageConfig struct {
+	BasePath string
+	BaseURL  string
+
+	// UploadURL is where PresignedPutURL points direct uploads: the API's
+	// own local-upload route, not BaseURL (which serves reads).
+	UploadURL string
+	// SigningSecret signs the HMAC token LocalStore embeds in its presigned
+	// PUT URLs; the local-upload route verifies it before writing to disk.
+	SigningSecret string
+}
+
+type S3StorageConfig struct {
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	Endpoint        string
+}
+
+type MinIOStorageConfig struct {
+	Endpoint        string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	UseSSL          bool
+}
+
+type COSStorageConfig struct {
+	Region    string
+	Bucket    string
+	SecretID  string
+	SecretKey string
+}
+
+type OSSStorageConfig struct {
+	Endpoint        string
+	Bucket          string
+	AccessKeyID     string
+	AccessKeySecret string
+}
+
+// AIConfig selects and configures the LLM backend used by internal/ai/llm.
+type AIConfig struct {
+	// Provider is one of: "ollama", "openai", "anthropic".
+	Provider string
+	Model    string
+	Host     string
+	APIKey   string
+}
+
+// Config holds application-wide configuration loaded from the environment.
+type Config struct {
+	AppEnv      string
+	DatabaseURL string
+	RedisAddr   string
+
+	MediaStorage MediaStorageConfig
+	AI           AIConfig
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// LoadConfig reads configuration from the environment.
+func LoadConfig() Config {
+	return Config{
+		AppEnv:      getEnv("APP_ENV", "development"),
+		DatabaseURL: os.Getenv("DATABASE_URL"),
+		RedisAddr:   getEnv("REDIS_ADDR", "localhost:6379"),
+
+		MediaStorage: MediaStorageConfig{
+			Driver: getEnv("MEDIA_STORAGE_DRIVER", "local"),
+			Local: LocalStorageConfig{
+				BasePath:      getEnv("MEDIA_LOCAL_BASE_PATH", "./uploads"),
+				BaseURL:       getEnv("MEDIA_LOCAL_BASE_URL", "/uploads"),
+				UploadURL:     getEnv("MEDIA_LOCAL_UPLOAD_URL", "/api/v1/media/local-upload"),
+				SigningSecret: os.Getenv("MEDIA_LOCAL_SIGNING_SECRET"),
+			},
+			S3: S3StorageConfig{
+				Region:          os.Getenv("MEDIA_S3_REGION"),
+				Bucket:          os.Getenv("MEDIA_S3_BUCKET"),
+				AccessKeyID:     os.Getenv("MEDIA_S3_ACCESS_KEY_ID"),
+				SecretAccessKey: os.Getenv("MEDIA_S3_SECRET_ACCESS_KEY"),
+				Endpoint:        os.Getenv("MEDIA_S3_ENDPOINT"),
+			},
+			MinIO: MinIOStorageConfig{
+				Endpoint:        os.Getenv("MEDIA_MINIO_ENDPOINT"),
+				Bucket:          os.Getenv("MEDIA_MINIO_BUCKET"),
+				AccessKeyID:     os.Getenv("MEDIA_MINIO_ACCESS_KEY_ID"),
+				SecretAccessKey: os.Getenv("MEDIA_MINIO_SECRET_ACCESS_KEY"),
+				UseSSL:          getEnv("MEDIA_MINIO_USE_SSL", "true") == "true",
+			},
+			COS: COSStorageConfig{
+				Region:    os.Getenv("MEDIA_COS_REGION"),
+				Bucket:    os.Getenv("MEDIA_COS_BUCKET"),
+				SecretID:  os.Getenv("MEDIA_COS_SECRET_ID"),
+				SecretKey: os.Getenv("MEDIA_COS_SECRET_KEY"),
+			},
+			OSS: OSSStorageConfig{
+				Endpoint:        os.Getenv("MEDIA_OSS_ENDPOINT"),
+				Bucket:          os.Getenv("MEDIA_OSS_BUCKET"),
+				AccessKeyID:     os.Getenv("MEDIA_OSS_ACCESS_KEY_ID"),
+				AccessKeySecret: os.Getenv("MEDIA_OSS_ACCESS_KEY_SECRET"),
+			},
+		},
+
+		AI: AIConfig{
+			Provider: getEnv("AI_PROVIDER", "ollama"),
+			Model:    os.Getenv("AI_MODEL"),
+			Host:     os.Getenv("AI_HOST"),
+			APIKey:   os.Getenv("AI_API_KEY"),
+		},
+	}
+}
+
+// ConnectDatabase opens the Postgres connection used across the app.
+func ConnectDatabase() *gorm.DB {
+	dsn := os.Getenv("DATABASE_URL")
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		log.Println("[config] failed to connect to database:", err)
+		return nil
+	}
+	return db
+}
+
+// ConnectRedis opens the Redis connection used by the cache service; asynq
+// opens its own connections to the same address.
+func ConnectRedis() *redis.Client {
+	client := redis.NewClient(&redis.Options{
+		Addr: getEnv("REDIS_ADDR", "localhost:6379"),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		log.Println("[config] failed to connect to redis:", err)
+		return nil
+	}
+	return client
+}
@@ -0,0 +1,138 @@
+package ai
+
+import (
+	"fmt"
+
+	"rag-searchbot-backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const defaultHistoryLimit = 10
+
+type ConversationRepositoryInterface interface {
+	Create(conversation *models.Conversation) error
+	GetByIDForUser(id, userID uuid.UUID) (*models.Conversation, error)
+	ListByPostAndUser(postID, userID uuid.UUID) ([]models.Conversation, error)
+	Rename(id, userID uuid.UUID, title string) error
+	Delete(id, userID uuid.UUID) error
+
+	AppendMessage(message *models.Message) error
+	DeleteMessage(id uuid.UUID) error
+	LastMessages(conversationID uuid.UUID, limit int) ([]models.Message, error)
+}
+
+type ConversationRepository struct {
+	db *gorm.DB
+}
+
+func NewConversationRepository(db *gorm.DB) *ConversationRepository {
+	return &ConversationRepository{db: db}
+}
+
+func (r *ConversationRepository) Create(conversation *models.Conversation) error {
+	if err := r.db.Create(conversation).Error; err != nil {
+		return fmt.Errorf("conversation repository: create: %w", err)
+	}
+	return nil
+}
+
+// GetByIDForUser loads a conversation by id, scoped to userID so one user
+// can never read, extend, or otherwise touch another user's conversation.
+func (r *ConversationRepository) GetByIDForUser(id, userID uuid.UUID) (*models.Conversation, error) {
+	var conversation models.Conversation
+	err := r.db.Preload("Messages", func(db *gorm.DB) *gorm.DB {
+		return db.Order("messages.created_at ASC")
+	}).First(&conversation, "id = ? AND user_id = ?", id, userID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("conversation repository: get by id for user: %w", err)
+	}
+	return &conversation, nil
+}
+
+func (r *ConversationRepository) ListByPostAndUser(postID, userID uuid.UUID) ([]models.Conversation, error) {
+	var conversations []models.Conversation
+	err := r.db.
+		Where("post_id = ? AND user_id = ?", postID, userID).
+		Order("updated_at DESC").
+		Find(&conversations).Error
+	if err != nil {
+		return nil, fmt.Errorf("conversation repository: list by post and user: %w", err)
+	}
+	return conversations, nil
+}
+
+func (r *ConversationRepository) Rename(id, userID uuid.UUID, title string) error {
+	res := r.db.Model(&models.Conversation{}).Where("id = ? AND user_id = ?", id, userID).Update("title", title)
+	if res.Error != nil {
+		return fmt.Errorf("conversation repository: rename: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *ConversationRepository) Delete(id, userID uuid.UUID) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var owned models.Conversation
+		if err := tx.Select("id").First(&owned, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("conversation_id = ?", id).Delete(&models.Message{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Conversation{}, "id = ?", id).Error
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return err
+		}
+		return fmt.Errorf("conversation repository: delete: %w", err)
+	}
+	return nil
+}
+
+func (r *ConversationRepository) AppendMessage(message *models.Message) error {
+	if err := r.db.Create(message).Error; err != nil {
+		return fmt.Errorf("conversation repository: append message: %w", err)
+	}
+	return r.db.Model(&models.Conversation{}).Where("id = ?", message.ConversationID).Update("updated_at", message.CreatedAt).Error
+}
+
+// DeleteMessage removes a single message by id, used to roll back the user
+// turn when the assistant's streamed reply fails mid-way.
+func (r *ConversationRepository) DeleteMessage(id uuid.UUID) error {
+	err := r.db.Delete(&models.Message{}, "id = ?", id).Error
+	if err != nil {
+		return fmt.Errorf("conversation repository: delete message: %w", err)
+	}
+	return nil
+}
+
+// LastMessages returns up to limit messages for conversationID, oldest first,
+// so callers can splice them directly into a prompt.
+func (r *ConversationRepository) LastMessages(conversationID uuid.UUID, limit int) ([]models.Message, error) {
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+
+	var messages []models.Message
+	err := r.db.
+		Where("conversation_id = ?", conversationID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&messages).Error
+	if err != nil {
+		return nil, fmt.Errorf("conversation repository: last messages: %w", err)
+	}
+
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	return messages, nil
+}
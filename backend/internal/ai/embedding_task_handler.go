@@ -0,0 +1,36 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"rag-searchbot-backend/internal/ai/retriever"
+
+	"github.com/hibiken/asynq"
+)
+
+// TaskTypeEmbeddingGenerated is the asynq task type whose handler
+// invalidates the retriever's cached BM25 index for a post, distinct from
+// the job that generates the embeddings (TaskEnqueuer.EnqueuePostEmbedding).
+// Nothing in this package enqueues a task of this type, so this handler runs
+// only when some producer does. Invalidating at enqueue time only (as
+// OpenAIMode does) still leaves a window where the job is in flight and the
+// cache is rebuilt from the old chunks.
+const TaskTypeEmbeddingGenerated = "ai:embedding_generated"
+
+type embeddingGeneratedPayload struct {
+	PostID string `json:"post_id"`
+}
+
+// HandleEmbeddingGenerated invalidates the cached BM25 term statistics for
+// the post named in the task payload. Register it on the asynq mux against
+// TaskTypeEmbeddingGenerated (see api/v1/ai.RegisterRoutes).
+func HandleEmbeddingGenerated(ctx context.Context, t *asynq.Task) error {
+	var payload embeddingGeneratedPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("ai: handle embedding generated: %w", err)
+	}
+	retriever.Invalidate(payload.PostID)
+	return nil
+}
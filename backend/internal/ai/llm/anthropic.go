@@ -0,0 +1,224 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"rag-searchbot-backend/config"
+)
+
+const anthropicVersion = "2023-06-01"
+
+// AnthropicProvider talks to the Anthropic Messages API, which streams SSE
+// as event:/data: pairs and carries content in "content_block_delta" events.
+type AnthropicProvider struct {
+	host   string
+	apiKey string
+	client *http.Client
+}
+
+func NewAnthropicProvider(cfg config.AIConfig) *AnthropicProvider {
+	host := cfg.Host
+	if host == "" {
+		host = "https://api.anthropic.com"
+	}
+	return &AnthropicProvider{host: host, apiKey: cfg.APIKey, client: http.DefaultClient}
+}
+
+type anthropicDeltaEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Type        string `json:"type"`
+		Text        string `json:"text"`
+		PartialJSON string `json:"partial_json"`
+	} `json:"delta"`
+	ContentBlock struct {
+		Type  string          `json:"type"`
+		ID    string          `json:"id"`
+		Name  string          `json:"name"`
+		Input json.RawMessage `json:"input"`
+	} `json:"content_block"`
+}
+
+func toAnthropicTools(tools []ToolSchema) []map[string]interface{} {
+	out := make([]map[string]interface{}, len(tools))
+	for i, t := range tools {
+		out[i] = map[string]interface{}{
+			"name":         t.Name,
+			"description":  t.Description,
+			"input_schema": t.Parameters,
+		}
+	}
+	return out
+}
+
+// splitSystem pulls out the leading "system" message; Anthropic takes it as
+// a top-level field rather than a message with role "system". It also
+// reshapes tool turns into Anthropic's Messages API shape: there is no
+// "tool" role, so a tool result becomes a "user" message carrying a
+// tool_result content block, and an assistant turn that made a tool call
+// gets a tool_use block alongside its text so the following tool_result can
+// reference it by id.
+func splitSystem(messages []Message) (system string, rest []map[string]interface{}) {
+	rest = make([]map[string]interface{}, 0, len(messages))
+	for _, m := range messages {
+		switch {
+		case m.Role == "system":
+			if system == "" {
+				system = m.Content
+			}
+		case m.Role == "tool":
+			rest = append(rest, map[string]interface{}{
+				"role": "user",
+				"content": []map[string]interface{}{
+					{"type": "tool_result", "tool_use_id": m.ToolCallID, "content": m.Content},
+				},
+			})
+		case m.Role == "assistant" && len(m.ToolCalls) > 0:
+			blocks := make([]map[string]interface{}, 0, len(m.ToolCalls)+1)
+			if m.Content != "" {
+				blocks = append(blocks, map[string]interface{}{"type": "text", "text": m.Content})
+			}
+			for _, tc := range m.ToolCalls {
+				var input interface{} = map[string]interface{}{}
+				if tc.ArgsJSON != "" {
+					_ = json.Unmarshal([]byte(tc.ArgsJSON), &input)
+				}
+				blocks = append(blocks, map[string]interface{}{"type": "tool_use", "id": tc.ID, "name": tc.Name, "input": input})
+			}
+			rest = append(rest, map[string]interface{}{"role": "assistant", "content": blocks})
+		default:
+			rest = append(rest, map[string]interface{}{"role": m.Role, "content": m.Content})
+		}
+	}
+	return system, rest
+}
+
+func (p *AnthropicProvider) Chat(ctx context.Context, req ChatRequest) (<-chan ChatDelta, error) {
+	system, messages := splitSystem(req.Messages)
+
+	payload := map[string]interface{}{
+		"model":      req.Model,
+		"stream":     true,
+		"max_tokens": 4096,
+		"messages":   messages,
+	}
+	if system != "" {
+		payload["system"] = system
+	}
+	if len(req.Tools) > 0 {
+		payload["tools"] = toAnthropicTools(req.Tools)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("anthropic provider: marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.host+"/v1/messages", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("anthropic provider: build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-api-key", p.apiKey)
+	httpReq.Header.Set("anthropic-version", anthropicVersion)
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("anthropic provider: request: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("anthropic provider: unexpected status %d", resp.StatusCode)
+	}
+
+	deltas := make(chan ChatDelta)
+	go func() {
+		defer close(deltas)
+		defer resp.Body.Close()
+
+		var currentTool *ToolCall
+		var eventType string
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+		for scanner.Scan() {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			line := strings.TrimSpace(scanner.Text())
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				eventType = strings.TrimPrefix(line, "event: ")
+				continue
+			case strings.HasPrefix(line, "data: "):
+				// handled below
+			default:
+				continue
+			}
+
+			raw := strings.TrimPrefix(line, "data: ")
+			if raw == "" {
+				continue
+			}
+
+			var event anthropicDeltaEvent
+			if err := json.Unmarshal([]byte(raw), &event); err != nil {
+				continue
+			}
+
+			switch eventType {
+			case "content_block_start":
+				if event.ContentBlock.Type == "tool_use" {
+					currentTool = &ToolCall{ID: event.ContentBlock.ID, Name: event.ContentBlock.Name}
+				}
+			case "content_block_delta":
+				switch event.Delta.Type {
+				case "text_delta":
+					select {
+					case deltas <- ChatDelta{Content: event.Delta.Text}:
+					case <-ctx.Done():
+						return
+					}
+				case "input_json_delta":
+					if currentTool != nil {
+						currentTool.ArgsJSON += event.Delta.PartialJSON
+					}
+				}
+			case "content_block_stop":
+				if currentTool != nil {
+					select {
+					case deltas <- ChatDelta{ToolCall: currentTool}:
+					case <-ctx.Done():
+						return
+					}
+					currentTool = nil
+				}
+			case "message_stop":
+				select {
+				case deltas <- ChatDelta{Done: true}:
+				case <-ctx.Done():
+				}
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			select {
+			case deltas <- ChatDelta{Err: fmt.Errorf("anthropic provider: read stream: %w", err)}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return deltas, nil
+}
@@ -0,0 +1,175 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"rag-searchbot-backend/config"
+)
+
+// OllamaProvider talks to Ollama's /api/chat, which streams
+// newline-delimited JSON objects (not SSE) shaped like
+// {"message": {"role": "...", "content": "..."}, "done": false}.
+type OllamaProvider struct {
+	host   string
+	client *http.Client
+}
+
+func NewOllamaProvider(cfg config.AIConfig) *OllamaProvider {
+	return &OllamaProvider{host: cfg.Host, client: http.DefaultClient}
+}
+
+type ollamaFunctionCall struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+type ollamaToolCall struct {
+	Function ollamaFunctionCall `json:"function"`
+}
+
+type ollamaMessage struct {
+	Role      string           `json:"role"`
+	Content   string           `json:"content"`
+	ToolCalls []ollamaToolCall `json:"tool_calls,omitempty"`
+}
+
+type ollamaChatLine struct {
+	Message ollamaMessage `json:"message"`
+	Done    bool          `json:"done"`
+}
+
+// toOllamaTools mirrors the OpenAI-compatible tool schema Ollama's
+// /api/chat expects: {"type": "function", "function": {...}}.
+func toOllamaTools(tools []ToolSchema) []map[string]interface{} {
+	out := make([]map[string]interface{}, len(tools))
+	for i, t := range tools {
+		out[i] = map[string]interface{}{
+			"type": "function",
+			"function": map[string]interface{}{
+				"name":        t.Name,
+				"description": t.Description,
+				"parameters":  t.Parameters,
+			},
+		}
+	}
+	return out
+}
+
+func toOllamaMessages(msgs []Message) []ollamaMessage {
+	out := make([]ollamaMessage, len(msgs))
+	for i, m := range msgs {
+		om := ollamaMessage{Role: m.Role, Content: m.Content}
+		for _, tc := range m.ToolCalls {
+			args := tc.ArgsJSON
+			if args == "" {
+				args = "{}"
+			}
+			om.ToolCalls = append(om.ToolCalls, ollamaToolCall{
+				Function: ollamaFunctionCall{Name: tc.Name, Arguments: json.RawMessage(args)},
+			})
+		}
+		out[i] = om
+	}
+	return out
+}
+
+func (p *OllamaProvider) Chat(ctx context.Context, req ChatRequest) (<-chan ChatDelta, error) {
+	messages := toOllamaMessages(req.Messages)
+
+	payload := map[string]interface{}{
+		"model":    req.Model,
+		"stream":   true,
+		"messages": messages,
+	}
+	if len(req.Tools) > 0 {
+		payload["tools"] = toOllamaTools(req.Tools)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("ollama provider: marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.host+"/api/chat", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("ollama provider: build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("ollama provider: request: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("ollama provider: unexpected status %d", resp.StatusCode)
+	}
+
+	deltas := make(chan ChatDelta)
+	go func() {
+		defer close(deltas)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+		for scanner.Scan() {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+
+			var parsed ollamaChatLine
+			if err := json.Unmarshal(line, &parsed); err != nil {
+				continue
+			}
+
+			if parsed.Message.Content != "" {
+				select {
+				case deltas <- ChatDelta{Content: parsed.Message.Content}:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			// Unlike OpenAI's fragmented tool_calls deltas, Ollama sends each
+			// tool call whole in a single message, so no cross-line
+			// accumulation is needed.
+			for _, tc := range parsed.Message.ToolCalls {
+				select {
+				case deltas <- ChatDelta{ToolCall: &ToolCall{Name: tc.Function.Name, ArgsJSON: string(tc.Function.Arguments)}}:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if parsed.Done {
+				select {
+				case deltas <- ChatDelta{Done: true}:
+				case <-ctx.Done():
+				}
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			select {
+			case deltas <- ChatDelta{Err: fmt.Errorf("ollama provider: read stream: %w", err)}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return deltas, nil
+}
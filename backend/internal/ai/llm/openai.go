@@ -0,0 +1,210 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"rag-searchbot-backend/config"
+)
+
+// OpenAIProvider talks to any OpenAI-compatible /v1/chat/completions
+// endpoint, which streams SSE frames prefixed with "data: " and terminates
+// with a literal "data: [DONE]" frame.
+type OpenAIProvider struct {
+	host   string
+	apiKey string
+	client *http.Client
+}
+
+func NewOpenAIProvider(cfg config.AIConfig) *OpenAIProvider {
+	return &OpenAIProvider{host: cfg.Host, apiKey: cfg.APIKey, client: http.DefaultClient}
+}
+
+type openAIFunctionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+type openAIToolCallDelta struct {
+	Index    int                `json:"index"`
+	ID       string             `json:"id"`
+	Function openAIFunctionCall `json:"function"`
+}
+
+type openAIChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content   string                `json:"content"`
+			ToolCalls []openAIToolCallDelta `json:"tool_calls"`
+		} `json:"delta"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+}
+
+func toOpenAITools(tools []ToolSchema) []map[string]interface{} {
+	out := make([]map[string]interface{}, len(tools))
+	for i, t := range tools {
+		out[i] = map[string]interface{}{
+			"type": "function",
+			"function": map[string]interface{}{
+				"name":        t.Name,
+				"description": t.Description,
+				"parameters":  t.Parameters,
+			},
+		}
+	}
+	return out
+}
+
+// toOpenAIMessages carries tool-call structure through to the wire: an
+// assistant turn that made a tool call needs its "tool_calls" array present
+// so the following "tool" message's "tool_call_id" has something to match,
+// or the API rejects the request.
+func toOpenAIMessages(msgs []Message) []map[string]interface{} {
+	out := make([]map[string]interface{}, len(msgs))
+	for i, m := range msgs {
+		msg := map[string]interface{}{"role": m.Role, "content": m.Content}
+		if m.ToolCallID != "" {
+			msg["tool_call_id"] = m.ToolCallID
+		}
+		if len(m.ToolCalls) > 0 {
+			calls := make([]map[string]interface{}, len(m.ToolCalls))
+			for j, tc := range m.ToolCalls {
+				calls[j] = map[string]interface{}{
+					"id":   tc.ID,
+					"type": "function",
+					"function": map[string]interface{}{
+						"name":      tc.Name,
+						"arguments": tc.ArgsJSON,
+					},
+				}
+			}
+			msg["tool_calls"] = calls
+		}
+		out[i] = msg
+	}
+	return out
+}
+
+func (p *OpenAIProvider) Chat(ctx context.Context, req ChatRequest) (<-chan ChatDelta, error) {
+	messages := toOpenAIMessages(req.Messages)
+
+	payload := map[string]interface{}{
+		"model":    req.Model,
+		"stream":   true,
+		"messages": messages,
+	}
+	if len(req.Tools) > 0 {
+		payload["tools"] = toOpenAITools(req.Tools)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("openai provider: marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.host+"/v1/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("openai provider: build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("openai provider: request: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("openai provider: unexpected status %d", resp.StatusCode)
+	}
+
+	deltas := make(chan ChatDelta)
+	go func() {
+		defer close(deltas)
+		defer resp.Body.Close()
+
+		// Tool call arguments arrive fragmented across several deltas,
+		// indexed by the tool call's position in the response.
+		toolCalls := map[int]*ToolCall{}
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+		for scanner.Scan() {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			line := strings.TrimSpace(scanner.Text())
+			if !strings.HasPrefix(line, "data: ") {
+				continue
+			}
+			raw := strings.TrimSpace(strings.TrimPrefix(line, "data: "))
+			if raw == "" {
+				continue
+			}
+			if raw == "[DONE]" {
+				for _, tc := range toolCalls {
+					select {
+					case deltas <- ChatDelta{ToolCall: tc}:
+					case <-ctx.Done():
+						return
+					}
+				}
+				select {
+				case deltas <- ChatDelta{Done: true}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			var chunk openAIChunk
+			if err := json.Unmarshal([]byte(raw), &chunk); err != nil {
+				continue
+			}
+			if len(chunk.Choices) == 0 {
+				continue
+			}
+
+			delta := chunk.Choices[0].Delta
+			if delta.Content != "" {
+				select {
+				case deltas <- ChatDelta{Content: delta.Content}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			for _, tc := range delta.ToolCalls {
+				existing, ok := toolCalls[tc.Index]
+				if !ok {
+					existing = &ToolCall{}
+					toolCalls[tc.Index] = existing
+				}
+				if tc.ID != "" {
+					existing.ID = tc.ID
+				}
+				if tc.Function.Name != "" {
+					existing.Name = tc.Function.Name
+				}
+				existing.ArgsJSON += tc.Function.Arguments
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			select {
+			case deltas <- ChatDelta{Err: fmt.Errorf("openai provider: read stream: %w", err)}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return deltas, nil
+}
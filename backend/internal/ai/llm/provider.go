@@ -0,0 +1,68 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"rag-searchbot-backend/config"
+)
+
+// Message is a single chat turn, including tool-result turns that get
+// re-injected after a tool call before the model produces its final answer.
+type Message struct {
+	Role       string // "system", "user", "assistant", or "tool"
+	Content    string
+	ToolCallID string     // set on "tool" messages, echoing the call that produced them
+	ToolCalls  []ToolCall // set on "assistant" messages that requested a tool call, so it can be replayed on the next round
+}
+
+// ToolSchema describes a function the model may call.
+type ToolSchema struct {
+	Name        string
+	Description string
+	Parameters  map[string]interface{} // JSON schema
+}
+
+// ChatRequest is the provider-agnostic request every LLMProvider accepts.
+type ChatRequest struct {
+	Model    string
+	Messages []Message
+	Tools    []ToolSchema
+}
+
+// ToolCall is a function call the model asked the caller to dispatch.
+type ToolCall struct {
+	ID       string
+	Name     string
+	ArgsJSON string
+}
+
+// ChatDelta is one increment of a streamed response: either a piece of
+// assistant text, a tool call, the terminal delta with Done set, or a read
+// error with Err set.
+type ChatDelta struct {
+	Content  string
+	ToolCall *ToolCall
+	Done     bool
+	Err      error
+}
+
+// LLMProvider streams a chat completion. Implementations own their wire
+// protocol (NDJSON, SSE, ...) and must stop sending on ctx.Done().
+type LLMProvider interface {
+	Chat(ctx context.Context, req ChatRequest) (<-chan ChatDelta, error)
+}
+
+// New builds the LLMProvider selected by cfg.Provider.
+func New(cfg config.AIConfig) (LLMProvider, error) {
+	switch cfg.Provider {
+	case "", "ollama":
+		return NewOllamaProvider(cfg), nil
+	case "openai":
+		return NewOpenAIProvider(cfg), nil
+	case "anthropic":
+		return NewAnthropicProvider(cfg), nil
+	default:
+		return nil, fmt.Errorf("llm: unknown provider %q", cfg.Provider)
+	}
+}
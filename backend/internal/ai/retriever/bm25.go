@@ -0,0 +1,88 @@
+package retriever
+
+import (
+	"math"
+	"regexp"
+	"strings"
+)
+
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+var tokenPattern = regexp.MustCompile(`[a-zA-Z0-9_]+`)
+
+// tokenize lower-cases and splits on non-alphanumeric boundaries, which is
+// enough to catch exact matches on names, IDs, and code identifiers that
+// cosine similarity over embeddings tends to miss.
+func tokenize(text string) []string {
+	return tokenPattern.FindAllString(strings.ToLower(text), -1)
+}
+
+// bm25Index holds the per-post term statistics BM25 needs: document
+// frequency per term, per-document term counts, and average document length.
+type bm25Index struct {
+	docTermFreqs []map[string]int
+	docLen       []int
+	avgDocLen    float64
+	docFreq      map[string]int
+	n            int
+}
+
+func buildBM25Index(docs []string) *bm25Index {
+	idx := &bm25Index{
+		docTermFreqs: make([]map[string]int, len(docs)),
+		docLen:       make([]int, len(docs)),
+		docFreq:      make(map[string]int),
+		n:            len(docs),
+	}
+
+	var totalLen int
+	for i, doc := range docs {
+		tokens := tokenize(doc)
+		freqs := make(map[string]int, len(tokens))
+		for _, t := range tokens {
+			freqs[t]++
+		}
+		idx.docTermFreqs[i] = freqs
+		idx.docLen[i] = len(tokens)
+		totalLen += len(tokens)
+
+		for term := range freqs {
+			idx.docFreq[term]++
+		}
+	}
+
+	if idx.n > 0 {
+		idx.avgDocLen = float64(totalLen) / float64(idx.n)
+	}
+	return idx
+}
+
+func (idx *bm25Index) idf(term string) float64 {
+	df := idx.docFreq[term]
+	return math.Log((float64(idx.n)-float64(df)+0.5)/(float64(df)+0.5) + 1)
+}
+
+// score returns the BM25 score of the query against document i.
+func (idx *bm25Index) score(queryTokens []string, i int) float64 {
+	if i >= idx.n {
+		return 0
+	}
+
+	freqs := idx.docTermFreqs[i]
+	docLen := float64(idx.docLen[i])
+
+	var score float64
+	for _, term := range queryTokens {
+		tf := float64(freqs[term])
+		if tf == 0 {
+			continue
+		}
+		numerator := tf * (bm25K1 + 1)
+		denominator := tf + bm25K1*(1-bm25B+bm25B*docLen/idx.avgDocLen)
+		score += idx.idf(term) * (numerator / denominator)
+	}
+	return score
+}
@@ -0,0 +1,44 @@
+package retriever
+
+import "sync"
+
+// indexCache memoizes each post's BM25 index so a multi-turn conversation
+// doesn't re-tokenize every chunk on every question. It is invalidated
+// whenever a post's embeddings change (see Invalidate).
+type indexCache struct {
+	mu      sync.RWMutex
+	indexes map[string]*bm25Index
+}
+
+var bm25Cache = &indexCache{indexes: make(map[string]*bm25Index)}
+
+func (c *indexCache) getOrBuild(postID string, docs []string) *bm25Index {
+	c.mu.RLock()
+	idx, ok := c.indexes[postID]
+	c.mu.RUnlock()
+	if ok {
+		return idx
+	}
+
+	idx = buildBM25Index(docs)
+
+	c.mu.Lock()
+	c.indexes[postID] = idx
+	c.mu.Unlock()
+
+	return idx
+}
+
+func (c *indexCache) invalidate(postID string) {
+	c.mu.Lock()
+	delete(c.indexes, postID)
+	c.mu.Unlock()
+}
+
+// Invalidate drops the cached BM25 term statistics for postID. Call this
+// whenever a post's chunk embeddings are (re)generated, e.g. in
+// AIService.DisableOpenAIMode, so the next retrieval rebuilds from the
+// current chunk set.
+func Invalidate(postID string) {
+	bm25Cache.invalidate(postID)
+}
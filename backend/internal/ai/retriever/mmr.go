@@ -0,0 +1,50 @@
+package retriever
+
+import "rag-searchbot-backend/pkg/utils"
+
+// selectMMR greedily picks up to max candidates (at least min, when that
+// many exist), maximizing lambda*sim(q,c) - (1-lambda)*max(sim(c,selected))
+// at each step so the final set stays relevant to the query without being
+// near-duplicates of each other.
+func selectMMR(candidates []ScoredChunk, lambda float64, min, max int) []ScoredChunk {
+	target := max
+	if len(candidates) < target {
+		target = len(candidates)
+	}
+	if target < min && len(candidates) >= min {
+		target = min
+	}
+
+	remaining := make([]ScoredChunk, len(candidates))
+	copy(remaining, candidates)
+	selected := make([]ScoredChunk, 0, target)
+
+	for len(selected) < target {
+		bestIdx := 0
+		bestScore := mmrScore(remaining[0], selected, lambda)
+
+		for i := 1; i < len(remaining); i++ {
+			score := mmrScore(remaining[i], selected, lambda)
+			if score > bestScore {
+				bestScore = score
+				bestIdx = i
+			}
+		}
+
+		selected = append(selected, remaining[bestIdx])
+		remaining = append(remaining[:bestIdx], remaining[bestIdx+1:]...)
+	}
+
+	return selected
+}
+
+func mmrScore(candidate ScoredChunk, selected []ScoredChunk, lambda float64) float64 {
+	var redundancy float64
+	for _, s := range selected {
+		sim := utils.CosineSimilarity(candidate.Chunk.Vector, s.Chunk.Vector)
+		if sim > redundancy {
+			redundancy = sim
+		}
+	}
+	return lambda*candidate.CombinedScore - (1-lambda)*redundancy
+}
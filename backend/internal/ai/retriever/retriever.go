@@ -0,0 +1,132 @@
+package retriever
+
+import (
+	"sort"
+
+	"rag-searchbot-backend/pkg/utils"
+)
+
+// Chunk is the minimal view of a post's embedded chunk the retriever needs;
+// callers adapt their own chunk model to this.
+type Chunk struct {
+	ID     string
+	Text   string
+	Vector []float64
+}
+
+// ScoredChunk is a Chunk annotated with the scores that produced it, exposed
+// so callers can surface citations/debug info to the frontend.
+type ScoredChunk struct {
+	Chunk         Chunk
+	VectorScore   float64
+	BM25Score     float64
+	CombinedScore float64
+}
+
+// Options tunes the hybrid retrieval + MMR re-ranking pipeline.
+type Options struct {
+	// Alpha weights the vector score against the BM25 score:
+	// combined = alpha*vector + (1-alpha)*bm25.
+	Alpha float64
+	// CandidateK is how many hybrid-scored chunks are kept before MMR.
+	CandidateK int
+	// MMRLambda trades relevance against redundancy in MMR selection.
+	MMRLambda float64
+	// MinResults/MaxResults bound the final selection size.
+	MinResults int
+	MaxResults int
+}
+
+// DefaultOptions matches the repo's tuned defaults.
+func DefaultOptions() Options {
+	return Options{
+		Alpha:      0.6,
+		CandidateK: 20,
+		MMRLambda:  0.7,
+		MinResults: 3,
+		MaxResults: 5,
+	}
+}
+
+// Retrieve runs BM25 + vector hybrid scoring over chunks, normalizes and
+// combines the two signals, keeps the top CandidateK, and re-ranks them with
+// MMR to pick a final, non-redundant set of MinResults-MaxResults chunks.
+func Retrieve(postID, question string, questionEmbedding []float64, chunks []Chunk, opts Options) []ScoredChunk {
+	if len(chunks) == 0 {
+		return nil
+	}
+
+	docs := make([]string, len(chunks))
+	for i, c := range chunks {
+		docs[i] = c.Text
+	}
+	bm25Index := bm25Cache.getOrBuild(postID, docs)
+	queryTokens := tokenize(question)
+
+	scored := make([]ScoredChunk, len(chunks))
+	for i, c := range chunks {
+		scored[i] = ScoredChunk{
+			Chunk:       c,
+			VectorScore: utils.CosineSimilarity(c.Vector, questionEmbedding),
+			BM25Score:   bm25Index.score(queryTokens, i),
+		}
+	}
+
+	normalizeVectorScores(scored)
+	normalizeBM25Scores(scored)
+	for i := range scored {
+		scored[i].CombinedScore = opts.Alpha*scored[i].VectorScore + (1-opts.Alpha)*scored[i].BM25Score
+	}
+
+	sort.Slice(scored, func(i, j int) bool {
+		return scored[i].CombinedScore > scored[j].CombinedScore
+	})
+
+	candidateK := opts.CandidateK
+	if candidateK > len(scored) {
+		candidateK = len(scored)
+	}
+	candidates := scored[:candidateK]
+
+	return selectMMR(candidates, opts.MMRLambda, opts.MinResults, opts.MaxResults)
+}
+
+func normalizeVectorScores(scored []ScoredChunk) {
+	min, max := scored[0].VectorScore, scored[0].VectorScore
+	for _, s := range scored {
+		if s.VectorScore < min {
+			min = s.VectorScore
+		}
+		if s.VectorScore > max {
+			max = s.VectorScore
+		}
+	}
+	spread := max - min
+	for i := range scored {
+		if spread == 0 {
+			scored[i].VectorScore = 0
+			continue
+		}
+		scored[i].VectorScore = (scored[i].VectorScore - min) / spread
+	}
+}
+
+func normalizeBM25Scores(scored []ScoredChunk) {
+	min, max := scored[0].BM25Score, scored[0].BM25Score
+	for _, s := range scored {
+		if s.BM25Score < min {
+			min = s.BM25Score
+		}
+		if s.BM25Score > max {
+			max = s.BM25Score
+		}
+	}
+	spread := max - min
+	for i := range scored {
+		if spread == 0 {
+			scored[i].BM25Score = 0
+			continue
+		}
+		scored[i].BM25Score = (scored[i].BM25Score - min) / spread
+	}
+}
@@ -1,17 +1,15 @@
 package ai
 
 import (
-	"bufio"
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
-	"os"
+	"rag-searchbot-backend/internal/ai/llm"
+	"rag-searchbot-backend/internal/ai/retriever"
 	"rag-searchbot-backend/internal/models"
 	"rag-searchbot-backend/internal/post"
-	"rag-searchbot-backend/pkg/utils"
-	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -20,13 +18,28 @@ type AIService struct {
 	PosRepo      post.PostRepositoryInterface
 	TaskEnqueuer *TaskEnqueuer
 	AIRepo       AIRepositoryInterface
+	ConvRepo     ConversationRepositoryInterface
+
+	Provider llm.LLMProvider
+	Model    string
+
+	// RelatedPosts backs the list_related_posts tool; nil disables it.
+	RelatedPosts RelatedPostsFinder
+
+	// HistoryLimit caps how many prior messages ChatStream loads into the
+	// prompt for a conversation.
+	HistoryLimit int
 }
 
-func NewAIService(posRepo post.PostRepositoryInterface, enqueuer *TaskEnqueuer, aiRepo AIRepositoryInterface) *AIService {
+func NewAIService(posRepo post.PostRepositoryInterface, enqueuer *TaskEnqueuer, aiRepo AIRepositoryInterface, convRepo ConversationRepositoryInterface, provider llm.LLMProvider, model string) *AIService {
 	return &AIService{
 		PosRepo:      posRepo,
 		TaskEnqueuer: enqueuer,
 		AIRepo:       aiRepo,
+		ConvRepo:     convRepo,
+		Provider:     provider,
+		Model:        model,
+		HistoryLimit: defaultHistoryLimit,
 	}
 }
 
@@ -52,6 +65,12 @@ func (s *AIService) OpenAIMode(postID string, userData *models.User) (bool, erro
 		return false, err
 	}
 
+	// Drop any cached index for the post now. A retrieval made before the
+	// job finishes can still rebuild and re-cache it from the old chunk set;
+	// HandleEmbeddingGenerated clears it again when a task of type
+	// TaskTypeEmbeddingGenerated is handled.
+	retriever.Invalidate(postID)
+
 	return true, nil
 }
 
@@ -85,14 +104,18 @@ func (s *AIService) DisableOpenAIMode(postID string, userData *models.User) (boo
 		return false, err
 	}
 
+	// the chunk set changed, so any cached BM25 term statistics are stale
+	retriever.Invalidate(postID)
+
 	return true, nil
 }
 
 type AskRequest struct {
-	Question string `json:"question"`
+	Question       string  `json:"question"`
+	ConversationID *string `json:"conversation_id,omitempty"`
 }
 
-func (s *AIService) ChatStream(postID string, userData *models.User, prompt string, onChunk func(string)) error {
+func (s *AIService) ChatStream(ctx context.Context, postID string, userData *models.User, prompt string, onChunk func(string)) error {
 	var req AskRequest
 	if err := json.Unmarshal([]byte(prompt), &req); err != nil {
 		return err
@@ -103,120 +126,225 @@ func (s *AIService) ChatStream(postID string, userData *models.User, prompt stri
 		return fmt.Errorf("post not found or AI not enabled")
 	}
 
-	questionEmbedding, err := GetEmbedding(req.Question)
+	conversation, err := s.resolveConversation(req.ConversationID, post.ID, userData.ID, req.Question)
 	if err != nil {
-		return err
+		return fmt.Errorf("chat stream: resolve conversation: %w", err)
 	}
 
-	chunks, err := s.PosRepo.GetEmbeddingByPostID(postID)
+	history, err := s.ConvRepo.LastMessages(conversation.ID, s.HistoryLimit)
+	if err != nil {
+		return fmt.Errorf("chat stream: load history: %w", err)
+	}
+
+	// Blend the previous user turn into the embedding query so retrieval
+	// stays on-topic across a multi-turn conversation instead of only
+	// matching the latest, possibly elliptical, question.
+	embeddingQuery := req.Question
+	if lastUserTurn := lastUserMessage(history); lastUserTurn != "" {
+		embeddingQuery = lastUserTurn + "\n" + req.Question
+	}
+
+	questionEmbedding, err := GetEmbedding(embeddingQuery)
 	if err != nil {
 		return err
 	}
 
-	type ScoredChunk struct {
-		Text  string
-		Score float64
+	chunks, err := s.PosRepo.GetEmbeddingByPostID(postID)
+	if err != nil {
+		return err
 	}
 
-	var scoredChunks []ScoredChunk
-	for _, chunk := range chunks {
-		score := utils.CosineSimilarity(chunk.Vector.Slice(), questionEmbedding)
-		scoredChunks = append(scoredChunks, ScoredChunk{
-			Text:  chunk.Content,
-			Score: score,
-		})
+	retrieverChunks := make([]retriever.Chunk, len(chunks))
+	for i, chunk := range chunks {
+		retrieverChunks[i] = retriever.Chunk{ID: chunk.ID.String(), Text: chunk.Content, Vector: chunk.Vector.Slice()}
 	}
 
-	sort.Slice(scoredChunks, func(i, j int) bool {
-		return scoredChunks[i].Score > scoredChunks[j].Score
-	})
+	topChunks := retriever.Retrieve(postID, embeddingQuery, questionEmbedding, retrieverChunks, retriever.DefaultOptions())
 
-	topChunks := []string{}
-	for i := 0; i < 3 && i < len(scoredChunks); i++ {
-		topChunks = append(topChunks, scoredChunks[i].Text)
+	texts := make([]string, len(topChunks))
+	for i, c := range topChunks {
+		texts[i] = c.Chunk.Text
 	}
 
-	fullContext := strings.Join(topChunks, "\n\n")
+	fullContext := strings.Join(texts, "\n\n")
 	if fullContext == "" {
 		fullContext = "There is no relevant information from the document. Answer the question as best as you can or inform the user you cannot answer."
 	}
 
-	// Call streaming LLM
-	return StreamAIResponse(fullContext, req.Question, onChunk)
-}
+	if debug, err := json.Marshal(map[string]interface{}{"citations": citationsFor(topChunks)}); err == nil {
+		onChunk(string(debug))
+	}
 
-func StreamAIResponse(context, question string, onChunk func(string)) error {
-	models := os.Getenv("AI_MODEL")
-	payload := map[string]interface{}{
-		"model":  models,
-		"stream": true,
-		"messages": []map[string]string{
-			{"role": "system", "content": context},
-			{"role": "user", "content": question},
-		},
+	messages := make([]llm.Message, 0, len(history)+2)
+	messages = append(messages, llm.Message{Role: "system", Content: fullContext})
+	for _, m := range history {
+		messages = append(messages, llm.Message{Role: string(m.Role), Content: m.Content})
+	}
+	messages = append(messages, llm.Message{Role: "user", Content: req.Question})
+
+	userMessage := &models.Message{
+		ID:             uuid.New(),
+		ConversationID: conversation.ID,
+		Role:           models.MessageRoleUser,
+		Content:        req.Question,
+		CreatedAt:      time.Now(),
+	}
+	if err := s.ConvRepo.AppendMessage(userMessage); err != nil {
+		return fmt.Errorf("chat stream: save user message: %w", err)
 	}
 
-	body, _ := json.Marshal(payload)
-	ollamaURL := os.Getenv("AI_HOST")
-	resp, err := http.Post(ollamaURL+"/api/generate", "application/json", bytes.NewBuffer(body))
+	assistantReply, err := s.streamWithTools(ctx, postID, messages, onChunk)
 	if err != nil {
-		return err
+		if delErr := s.ConvRepo.DeleteMessage(userMessage.ID); delErr != nil {
+			return fmt.Errorf("chat stream: %w (rollback also failed: %v)", err, delErr)
+		}
+		return fmt.Errorf("chat stream: %w", err)
 	}
 
-	fmt.Println("Response status:", resp.StatusCode)
-	defer resp.Body.Close()
+	assistantMessage := &models.Message{
+		ID:             uuid.New(),
+		ConversationID: conversation.ID,
+		Role:           models.MessageRoleAssistant,
+		Content:        assistantReply,
+		CreatedAt:      time.Now(),
+	}
+	if err := s.ConvRepo.AppendMessage(assistantMessage); err != nil {
+		return fmt.Errorf("chat stream: save assistant message: %w", err)
+	}
 
-	reader := bufio.NewReader(resp.Body)
-	for {
-		line, err := reader.ReadBytes('\n')
+	return nil
+}
 
-		fmt.Println("Received line:", string(line))
+// resolveConversation loads the conversation named by conversationID, or
+// starts a new one for postID/userID if none was given, or if the given one
+// does not exist for userID or belongs to a different post.
+func (s *AIService) resolveConversation(conversationID *string, postID, userID uuid.UUID, question string) (*models.Conversation, error) {
+	if conversationID != nil && *conversationID != "" {
+		id, err := uuid.Parse(*conversationID)
 		if err != nil {
-			break
+			return nil, fmt.Errorf("invalid conversation_id: %w", err)
 		}
+		conversation, err := s.ConvRepo.GetByIDForUser(id, userID)
+		if err != nil {
+			return nil, err
+		}
+		if conversation != nil && conversation.PostID == postID {
+			return conversation, nil
+		}
+	}
 
-		if bytes.HasPrefix(line, []byte("data: ")) {
-			raw := bytes.TrimSpace(line[6:])
-
-			if len(raw) == 0 || bytes.Equal(raw, []byte("[DONE]")) {
-				continue
-			}
+	conversation := &models.Conversation{
+		ID:     uuid.New(),
+		PostID: postID,
+		UserID: userID,
+		Title:  truncateTitle(question),
+	}
+	if err := s.ConvRepo.Create(conversation); err != nil {
+		return nil, err
+	}
+	return conversation, nil
+}
 
-			var chunk map[string]interface{}
-			if err := json.Unmarshal(raw, &chunk); err != nil {
-				continue
-			}
+// citation is the debug/citation info sent to the frontend for each chunk
+// ChatStream selected. It deliberately omits retriever.Chunk.Vector, which
+// would otherwise dump the full embedding of every cited chunk to the
+// client.
+type citation struct {
+	ID            string  `json:"id"`
+	Text          string  `json:"text"`
+	VectorScore   float64 `json:"vector_score"`
+	BM25Score     float64 `json:"bm25_score"`
+	CombinedScore float64 `json:"combined_score"`
+}
 
-			if message, ok := chunk["message"].(map[string]interface{}); ok {
-				if content, ok := message["content"].(string); ok {
-					// ส่งแบบ JSON ที่ฝั่ง client รับง่าย
-					jsonEncoded, _ := json.Marshal(map[string]string{"text": content})
-					onChunk(string(jsonEncoded))
-				}
-			}
+func citationsFor(chunks []retriever.ScoredChunk) []citation {
+	out := make([]citation, len(chunks))
+	for i, c := range chunks {
+		out[i] = citation{
+			ID:            c.Chunk.ID,
+			Text:          c.Chunk.Text,
+			VectorScore:   c.VectorScore,
+			BM25Score:     c.BM25Score,
+			CombinedScore: c.CombinedScore,
 		}
 	}
-	return nil
+	return out
 }
 
-type OllamaRequest struct {
-	Model  string `json:"model"`
-	Prompt string `json:"prompt"`
-	Stream bool   `json:"stream"` // false = return full output
+func lastUserMessage(history []models.Message) string {
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Role == models.MessageRoleUser {
+			return history[i].Content
+		}
+	}
+	return ""
 }
 
-type OllamaResponse struct {
-	Response string `json:"response"`
-	Done     bool   `json:"done"`
+func truncateTitle(question string) string {
+	const maxLen = 80
+	runes := []rune(question)
+	if len(runes) <= maxLen {
+		return question
+	}
+	return string(runes[:maxLen])
 }
 
-type ChatRequest struct {
-	Model  string `json:"model"`
-	Prompt string `json:"prompt"`
-}
+// streamWithTools drives the provider-agnostic streaming loop: it streams
+// deltas to onChunk as they arrive, and when the model asks for a tool call
+// it dispatches the tool, re-injects the result as a "tool" message, and
+// re-invokes the provider for the next round. ctx cancellation aborts the
+// upstream LLM call instead of leaking the streaming goroutine.
+func (s *AIService) streamWithTools(ctx context.Context, postID string, messages []llm.Message, onChunk func(string)) (string, error) {
+	var assistantReply strings.Builder
+
+	for round := 0; round < maxToolRounds; round++ {
+		deltas, err := s.Provider.Chat(ctx, llm.ChatRequest{
+			Model:    s.Model,
+			Messages: messages,
+			Tools:    builtinTools(),
+		})
+		if err != nil {
+			return "", fmt.Errorf("stream with tools: %w", err)
+		}
+
+		var roundText strings.Builder
+		var pendingCall *llm.ToolCall
+
+		for delta := range deltas {
+			if delta.Err != nil {
+				return "", fmt.Errorf("stream with tools: %w", delta.Err)
+			}
+			if delta.Content != "" {
+				roundText.WriteString(delta.Content)
+				encoded, _ := json.Marshal(map[string]string{"text": delta.Content})
+				onChunk(string(encoded))
+			}
+			if delta.ToolCall != nil {
+				pendingCall = delta.ToolCall
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return "", fmt.Errorf("stream with tools: %w", err)
+		}
+
+		assistantReply.WriteString(roundText.String())
+
+		if pendingCall == nil {
+			return assistantReply.String(), nil
+		}
+
+		result, err := s.dispatchTool(postID, *pendingCall)
+		if err != nil {
+			return "", fmt.Errorf("stream with tools: %w", err)
+		}
+
+		messages = append(messages,
+			llm.Message{Role: "assistant", Content: roundText.String(), ToolCalls: []llm.ToolCall{*pendingCall}},
+			llm.Message{Role: "tool", Content: result, ToolCallID: pendingCall.ID},
+		)
+	}
 
-type ChatResponse struct {
-	Response string `json:"response"`
+	return "", fmt.Errorf("stream with tools: exceeded %d tool-call rounds", maxToolRounds)
 }
 
 func (s *AIService) CreateChat(chat *models.AIResponse, postID string, user *models.User) error {
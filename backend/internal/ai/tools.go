@@ -0,0 +1,142 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"rag-searchbot-backend/internal/ai/llm"
+	"rag-searchbot-backend/internal/ai/retriever"
+)
+
+const (
+	toolSearchPostChunks = "search_post_chunks"
+	toolListRelatedPosts = "list_related_posts"
+
+	maxToolRounds = 4
+)
+
+// RelatedPost is one hit returned by the list_related_posts tool.
+type RelatedPost struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// RelatedPostsFinder backs the list_related_posts tool. It is optional:
+// when unset, the tool reports itself unavailable instead of the service
+// failing to build.
+type RelatedPostsFinder interface {
+	ListRelated(topic string, limit int) ([]RelatedPost, error)
+}
+
+// builtinTools describes the functions ChatStream registers with the LLM
+// provider so it can pull more context mid-answer instead of only working
+// from the initial retrieval pass.
+func builtinTools() []llm.ToolSchema {
+	return []llm.ToolSchema{
+		{
+			Name:        toolSearchPostChunks,
+			Description: "Search this post's own content for passages relevant to a follow-up query.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"query": map[string]interface{}{"type": "string"},
+					"k":     map[string]interface{}{"type": "integer", "description": "max passages to return"},
+				},
+				"required": []string{"query"},
+			},
+		},
+		{
+			Name:        toolListRelatedPosts,
+			Description: "List other posts related to a topic, for cross-referencing.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"topic": map[string]interface{}{"type": "string"},
+				},
+				"required": []string{"topic"},
+			},
+		},
+	}
+}
+
+// dispatchTool runs a tool call the provider asked for and returns the
+// JSON-encoded result to feed back as a "tool" message.
+func (s *AIService) dispatchTool(postID string, call llm.ToolCall) (string, error) {
+	switch call.Name {
+	case toolSearchPostChunks:
+		return s.searchPostChunks(postID, call.ArgsJSON)
+	case toolListRelatedPosts:
+		return s.listRelatedPosts(call.ArgsJSON)
+	default:
+		return "", fmt.Errorf("tool: unknown tool %q", call.Name)
+	}
+}
+
+func (s *AIService) searchPostChunks(postID, argsJSON string) (string, error) {
+	var args struct {
+		Query string `json:"query"`
+		K     int    `json:"k"`
+	}
+	if err := json.Unmarshal([]byte(argsJSON), &args); err != nil {
+		return "", fmt.Errorf("tool: search_post_chunks: bad args: %w", err)
+	}
+	if args.K <= 0 {
+		args.K = 3
+	}
+
+	embedding, err := GetEmbedding(args.Query)
+	if err != nil {
+		return "", fmt.Errorf("tool: search_post_chunks: embed query: %w", err)
+	}
+
+	chunks, err := s.PosRepo.GetEmbeddingByPostID(postID)
+	if err != nil {
+		return "", fmt.Errorf("tool: search_post_chunks: load chunks: %w", err)
+	}
+
+	retrieverChunks := make([]retriever.Chunk, len(chunks))
+	for i, c := range chunks {
+		retrieverChunks[i] = retriever.Chunk{ID: c.ID.String(), Text: c.Content, Vector: c.Vector.Slice()}
+	}
+
+	opts := retriever.DefaultOptions()
+	opts.MinResults, opts.MaxResults = args.K, args.K
+
+	results := retriever.Retrieve(postID, args.Query, embedding, retrieverChunks, opts)
+
+	texts := make([]string, len(results))
+	for i, r := range results {
+		texts[i] = r.Chunk.Text
+	}
+
+	out, err := json.Marshal(map[string]interface{}{"passages": texts})
+	if err != nil {
+		return "", fmt.Errorf("tool: search_post_chunks: encode result: %w", err)
+	}
+	return string(out), nil
+}
+
+func (s *AIService) listRelatedPosts(argsJSON string) (string, error) {
+	if s.RelatedPosts == nil {
+		out, _ := json.Marshal(map[string]interface{}{"error": "list_related_posts is not available"})
+		return string(out), nil
+	}
+
+	var args struct {
+		Topic string `json:"topic"`
+	}
+	if err := json.Unmarshal([]byte(argsJSON), &args); err != nil {
+		return "", fmt.Errorf("tool: list_related_posts: bad args: %w", err)
+	}
+
+	posts, err := s.RelatedPosts.ListRelated(args.Topic, 5)
+	if err != nil {
+		return "", fmt.Errorf("tool: list_related_posts: %w", err)
+	}
+
+	out, err := json.Marshal(map[string]interface{}{"posts": posts})
+	if err != nil {
+		return "", fmt.Errorf("tool: list_related_posts: encode result: %w", err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,68 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"rag-searchbot-backend/config"
+	"rag-searchbot-backend/internal/ai"
+	"rag-searchbot-backend/internal/ai/llm"
+	"rag-searchbot-backend/internal/media"
+	"rag-searchbot-backend/internal/post"
+
+	"github.com/hibiken/asynq"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Container wires together the services shared across api/v1 route groups.
+type Container struct {
+	// Ctx is the application-lifetime context, cancelled on SIGINT/SIGTERM.
+	// Background work (cron jobs, queued enqueues) should derive from it;
+	// HTTP handlers should keep using the request's own context instead.
+	Ctx context.Context
+
+	MediaService *media.MediaService
+	AIService    *ai.AIService
+}
+
+// InitializeContainer builds every service the API depends on, ready to be
+// handed to the api/v1 route registrars. ctx is the application-lifetime
+// context; it is cancelled on shutdown and stored on Container for
+// background work to observe.
+func InitializeContainer(
+	ctx context.Context,
+	cfg *config.Config,
+	db *gorm.DB,
+	logger *zap.Logger,
+	redisClient *redis.Client,
+	cacheTTL time.Duration,
+	asynqClient *asynq.Client,
+) (*Container, error) {
+	objectStore, err := media.NewObjectStore(cfg.MediaStorage)
+	if err != nil {
+		return nil, fmt.Errorf("container: init media store: %w", err)
+	}
+	mediaRepo := media.NewMediaRepository(db)
+	mediaService := media.NewMediaService(mediaRepo, objectStore, logger)
+
+	postRepo := post.NewPostRepository(db)
+	aiRepo := ai.NewAIRepository(db)
+	convRepo := ai.NewConversationRepository(db)
+	taskEnqueuer := ai.NewTaskEnqueuer(asynqClient)
+
+	llmProvider, err := llm.New(cfg.AI)
+	if err != nil {
+		return nil, fmt.Errorf("container: init llm provider: %w", err)
+	}
+
+	aiService := ai.NewAIService(postRepo, taskEnqueuer, aiRepo, convRepo, llmProvider, cfg.AI.Model)
+
+	return &Container{
+		Ctx:          ctx,
+		MediaService: mediaService,
+		AIService:    aiService,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"rag-searchbot-backend/config"
+
+	"github.com/tencentyun/cos-go-sdk-v5"
+)
+
+// COSStore stores objects in Tencent Cloud Object Storage.
+type COSStore struct {
+	client *cos.Client
+}
+
+func NewCOSStore(cfg config.COSStorageConfig) (*COSStore, error) {
+	bucketURL, err := url.Parse(fmt.Sprintf("https://%s.cos.%s.myqcloud.com", cfg.Bucket, cfg.Region))
+	if err != nil {
+		return nil, fmt.Errorf("cos store: parse bucket url: %w", err)
+	}
+
+	client := cos.NewClient(&cos.BaseURL{BucketURL: bucketURL}, &http.Client{
+		Transport: &cos.AuthorizationTransport{
+			SecretID:  cfg.SecretID,
+			SecretKey: cfg.SecretKey,
+		},
+	})
+
+	return &COSStore{client: client}, nil
+}
+
+func (s *COSStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	_, err := s.client.Object.Put(ctx, key, body, &cos.ObjectPutOptions{
+		ObjectPutHeaderOptions: &cos.ObjectPutHeaderOptions{ContentType: contentType, ContentLength: size},
+	})
+	if err != nil {
+		return fmt.Errorf("cos store: put: %w", err)
+	}
+	return nil
+}
+
+func (s *COSStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.client.Object.Get(ctx, key, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cos store: get: %w", err)
+	}
+	return resp.Body, nil
+}
+
+func (s *COSStore) Delete(ctx context.Context, key string) error {
+	if _, err := s.client.Object.Delete(ctx, key); err != nil {
+		return fmt.Errorf("cos store: delete: %w", err)
+	}
+	return nil
+}
+
+func (s *COSStore) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	marker := ""
+	for {
+		result, _, err := s.client.Bucket.Get(ctx, &cos.BucketGetOptions{Prefix: prefix, Marker: marker})
+		if err != nil {
+			return nil, fmt.Errorf("cos store: list: %w", err)
+		}
+		for _, obj := range result.Contents {
+			keys = append(keys, obj.Key)
+		}
+		if !result.IsTruncated {
+			break
+		}
+		marker = result.NextMarker
+	}
+	return keys, nil
+}
+
+func (s *COSStore) PresignedPutURL(ctx context.Context, key string, ttl time.Duration, contentType string) (string, error) {
+	u, err := s.client.Object.GetPresignedURL(ctx, http.MethodPut, key, s.client.GetCredential().SecretID, s.client.GetCredential().SecretKey, ttl, nil)
+	if err != nil {
+		return "", fmt.Errorf("cos store: presign put: %w", err)
+	}
+	return u.String(), nil
+}
+
+func (s *COSStore) PresignedGetURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	u, err := s.client.Object.GetPresignedURL(ctx, http.MethodGet, key, s.client.GetCredential().SecretID, s.client.GetCredential().SecretKey, ttl, nil)
+	if err != nil {
+		return "", fmt.Errorf("cos store: presign get: %w", err)
+	}
+	return u.String(), nil
+}
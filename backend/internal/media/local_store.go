@@ -0,0 +1,128 @@
+package media
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"rag-searchbot-backend/config"
+)
+
+// LocalStore stores objects on the API server's local disk. It has no real
+// notion of presigned URLs, so it signs a short-lived HMAC token over the
+// key instead and relies on the media routes (api/v1/media Handler.LocalUpload)
+// to validate it before writing the body to disk.
+type LocalStore struct {
+	basePath  string
+	baseURL   string
+	uploadURL string
+	secret    []byte
+}
+
+func NewLocalStore(cfg config.LocalStorageConfig) *LocalStore {
+	return &LocalStore{
+		basePath:  cfg.BasePath,
+		baseURL:   cfg.BaseURL,
+		uploadURL: cfg.UploadURL,
+		secret:    []byte(cfg.SigningSecret),
+	}
+}
+
+func (s *LocalStore) sign(key string, exp int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(fmt.Sprintf("%s:%d", key, exp)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyPutToken checks the HMAC token PresignedPutURL signed over key+exp
+// and that it hasn't expired, so api/v1/media's local-upload route can
+// authenticate the client's direct PUT without the normal bearer auth.
+func (s *LocalStore) VerifyPutToken(key string, exp int64, sig string) bool {
+	if time.Now().Unix() > exp {
+		return false
+	}
+	return hmac.Equal([]byte(s.sign(key, exp)), []byte(sig))
+}
+
+// pathFor joins key under basePath. Keys reaching here are expected to
+// already be namespaced/cleaned by MediaService, but filepath.Join alone
+// would still let a literal "../" key escape basePath, so clean it again
+// defensively before joining.
+func (s *LocalStore) pathFor(key string) string {
+	clean := filepath.FromSlash(strings.TrimPrefix(filepath.ToSlash(filepath.Clean("/"+key)), "/"))
+	return filepath.Join(s.basePath, clean)
+}
+
+func (s *LocalStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	path := s.pathFor(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("local store: mkdir: %w", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("local store: create: %w", err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, body); err != nil {
+		return fmt.Errorf("local store: write: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	f, err := os.Open(s.pathFor(key))
+	if err != nil {
+		return nil, fmt.Errorf("local store: open: %w", err)
+	}
+	return f, nil
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	if err := os.Remove(s.pathFor(key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("local store: remove: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	err := filepath.Walk(s.basePath, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(s.basePath, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if prefix == "" || strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("local store: list: %w", err)
+	}
+	return keys, nil
+}
+
+// PresignedPutURL returns the direct upload endpoint on this API instance,
+// since a local disk store has nothing external to presign against.
+func (s *LocalStore) PresignedPutURL(ctx context.Context, key string, ttl time.Duration, contentType string) (string, error) {
+	exp := time.Now().Add(ttl).Unix()
+	sig := s.sign(key, exp)
+	return fmt.Sprintf("%s/%s?exp=%d&sig=%s", s.uploadURL, key, exp, sig), nil
+}
+
+// PresignedGetURL returns the plain read URL under baseURL. ttl is ignored:
+// the URL is not signed and does not expire.
+func (s *LocalStore) PresignedGetURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return fmt.Sprintf("%s/%s", s.baseURL, key), nil
+}
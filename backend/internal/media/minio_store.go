@@ -0,0 +1,80 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"rag-searchbot-backend/config"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// MinIOStore stores objects in a self-hosted MinIO cluster.
+type MinIOStore struct {
+	client *minio.Client
+	bucket string
+}
+
+func NewMinIOStore(cfg config.MinIOStorageConfig) (*MinIOStore, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		Secure: cfg.UseSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("minio store: new client: %w", err)
+	}
+	return &MinIOStore{client: client, bucket: cfg.Bucket}, nil
+}
+
+func (s *MinIOStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, body, size, minio.PutObjectOptions{ContentType: contentType})
+	if err != nil {
+		return fmt.Errorf("minio store: put: %w", err)
+	}
+	return nil
+}
+
+func (s *MinIOStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("minio store: get: %w", err)
+	}
+	return obj, nil
+}
+
+func (s *MinIOStore) Delete(ctx context.Context, key string) error {
+	if err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("minio store: delete: %w", err)
+	}
+	return nil
+}
+
+func (s *MinIOStore) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	for obj := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if obj.Err != nil {
+			return nil, fmt.Errorf("minio store: list: %w", obj.Err)
+		}
+		keys = append(keys, obj.Key)
+	}
+	return keys, nil
+}
+
+func (s *MinIOStore) PresignedPutURL(ctx context.Context, key string, ttl time.Duration, contentType string) (string, error) {
+	u, err := s.client.PresignedPutObject(ctx, s.bucket, key, ttl)
+	if err != nil {
+		return "", fmt.Errorf("minio store: presign put: %w", err)
+	}
+	return u.String(), nil
+}
+
+func (s *MinIOStore) PresignedGetURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	u, err := s.client.PresignedGetObject(ctx, s.bucket, key, ttl, nil)
+	if err != nil {
+		return "", fmt.Errorf("minio store: presign get: %w", err)
+	}
+	return u.String(), nil
+}
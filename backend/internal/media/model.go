@@ -0,0 +1,31 @@
+package media
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status tracks the lifecycle of a media row created ahead of a direct
+// client upload to object storage.
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusReady   Status = "ready"
+	StatusFailed  Status = "failed"
+)
+
+// Media represents a single uploaded asset, regardless of which backend it
+// is ultimately stored on.
+type Media struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Key         string    `gorm:"uniqueIndex;not null"`
+	Driver      string    `gorm:"not null"`
+	ContentType string
+	Size        int64
+	Status      Status `gorm:"not null;default:pending"`
+	OwnerID     uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,86 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"rag-searchbot-backend/config"
+
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+)
+
+// OSSStore stores objects in Alibaba Cloud Object Storage Service.
+type OSSStore struct {
+	bucket *oss.Bucket
+}
+
+func NewOSSStore(cfg config.OSSStorageConfig) (*OSSStore, error) {
+	client, err := oss.New(cfg.Endpoint, cfg.AccessKeyID, cfg.AccessKeySecret)
+	if err != nil {
+		return nil, fmt.Errorf("oss store: new client: %w", err)
+	}
+	bucket, err := client.Bucket(cfg.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("oss store: bucket: %w", err)
+	}
+	return &OSSStore{bucket: bucket}, nil
+}
+
+func (s *OSSStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if err := s.bucket.PutObject(key, body, oss.ContentType(contentType)); err != nil {
+		return fmt.Errorf("oss store: put: %w", err)
+	}
+	return nil
+}
+
+func (s *OSSStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	body, err := s.bucket.GetObject(key)
+	if err != nil {
+		return nil, fmt.Errorf("oss store: get: %w", err)
+	}
+	return body, nil
+}
+
+func (s *OSSStore) Delete(ctx context.Context, key string) error {
+	if err := s.bucket.DeleteObject(key); err != nil {
+		return fmt.Errorf("oss store: delete: %w", err)
+	}
+	return nil
+}
+
+func (s *OSSStore) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	marker := ""
+	for {
+		result, err := s.bucket.ListObjects(oss.Prefix(prefix), oss.Marker(marker))
+		if err != nil {
+			return nil, fmt.Errorf("oss store: list: %w", err)
+		}
+		for _, obj := range result.Objects {
+			keys = append(keys, obj.Key)
+		}
+		if !result.IsTruncated {
+			break
+		}
+		marker = result.NextMarker
+	}
+	return keys, nil
+}
+
+func (s *OSSStore) PresignedPutURL(ctx context.Context, key string, ttl time.Duration, contentType string) (string, error) {
+	u, err := s.bucket.SignURL(key, oss.HTTPPut, int64(ttl.Seconds()), oss.ContentType(contentType))
+	if err != nil {
+		return "", fmt.Errorf("oss store: presign put: %w", err)
+	}
+	return u, nil
+}
+
+func (s *OSSStore) PresignedGetURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	u, err := s.bucket.SignURL(key, oss.HTTPGet, int64(ttl.Seconds()))
+	if err != nil {
+		return "", fmt.Errorf("oss store: presign get: %w", err)
+	}
+	return u, nil
+}
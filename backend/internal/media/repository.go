@@ -0,0 +1,69 @@
+package media
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type MediaRepositoryInterface interface {
+	Create(media *Media) error
+	Update(media *Media) error
+	GetByKey(key string) (*Media, error)
+	ListUnused() ([]Media, error)
+	Delete(id uuid.UUID) error
+}
+
+type MediaRepository struct {
+	db *gorm.DB
+}
+
+func NewMediaRepository(db *gorm.DB) *MediaRepository {
+	return &MediaRepository{db: db}
+}
+
+func (r *MediaRepository) Create(media *Media) error {
+	if err := r.db.Create(media).Error; err != nil {
+		return fmt.Errorf("media repository: create: %w", err)
+	}
+	return nil
+}
+
+func (r *MediaRepository) Update(media *Media) error {
+	if err := r.db.Save(media).Error; err != nil {
+		return fmt.Errorf("media repository: update: %w", err)
+	}
+	return nil
+}
+
+func (r *MediaRepository) GetByKey(key string) (*Media, error) {
+	var m Media
+	if err := r.db.Where("key = ?", key).First(&m).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("media repository: get by key: %w", err)
+	}
+	return &m, nil
+}
+
+// ListUnused returns media rows that are no longer referenced by any post,
+// i.e. candidates for MediaService.DeleteUnusedImages.
+func (r *MediaRepository) ListUnused() ([]Media, error) {
+	var list []Media
+	err := r.db.
+		Where("status = ? AND NOT EXISTS (SELECT 1 FROM post_images WHERE post_images.media_key = media.key)", StatusReady).
+		Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("media repository: list unused: %w", err)
+	}
+	return list, nil
+}
+
+func (r *MediaRepository) Delete(id uuid.UUID) error {
+	if err := r.db.Delete(&Media{}, "id = ?", id).Error; err != nil {
+		return fmt.Errorf("media repository: delete: %w", err)
+	}
+	return nil
+}
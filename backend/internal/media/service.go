@@ -0,0 +1,134 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const defaultPresignTTL = 15 * time.Minute
+
+type MediaService struct {
+	repo   MediaRepositoryInterface
+	store  ObjectStore
+	logger *zap.Logger
+}
+
+func NewMediaService(repo MediaRepositoryInterface, store ObjectStore, logger *zap.Logger) *MediaService {
+	return &MediaService{repo: repo, store: store, logger: logger}
+}
+
+// namespacedKey prefixes key with ownerID so one user can never presign or
+// finalize over another user's object, and cleans it so a client-supplied
+// "../../etc/passwd"-style key can't escape that namespace (and, for
+// LocalStore, the configured base directory).
+func namespacedKey(ownerID uuid.UUID, key string) (string, error) {
+	clean := strings.TrimPrefix(path.Clean("/"+key), "/")
+	if clean == "" || clean == "." {
+		return "", fmt.Errorf("media service: invalid key %q", key)
+	}
+	return ownerID.String() + "/" + clean, nil
+}
+
+// PresignUpload creates a pending Media row and returns a presigned PUT URL
+// plus the headers the client must send, so uploads go straight to object
+// storage instead of through the API.
+func (s *MediaService) PresignUpload(ownerID uuid.UUID, key, contentType string) (*Media, string, map[string]string, error) {
+	key, err := namespacedKey(ownerID, key)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	url, err := s.store.PresignedPutURL(context.Background(), key, defaultPresignTTL, contentType)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("media service: presign upload: %w", err)
+	}
+
+	media := &Media{
+		ID:          uuid.New(),
+		Key:         key,
+		ContentType: contentType,
+		Status:      StatusPending,
+		OwnerID:     ownerID,
+	}
+	if err := s.repo.Create(media); err != nil {
+		return nil, "", nil, fmt.Errorf("media service: presign upload: %w", err)
+	}
+
+	headers := map[string]string{"Content-Type": contentType}
+	return media, url, headers, nil
+}
+
+// FinalizeUpload is called by the client callback once the direct upload to
+// object storage succeeds, flipping the pending row to ready. ownerID must
+// match the media row's owner, so one user can't finalize another's upload.
+func (s *MediaService) FinalizeUpload(ownerID uuid.UUID, key string, size int64) (*Media, error) {
+	media, err := s.repo.GetByKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("media service: finalize upload: %w", err)
+	}
+	if media == nil {
+		return nil, fmt.Errorf("media service: finalize upload: no pending media for key %q", key)
+	}
+	if media.OwnerID != ownerID {
+		return nil, fmt.Errorf("media service: finalize upload: key %q does not belong to this user", key)
+	}
+
+	media.Status = StatusReady
+	media.Size = size
+	if err := s.repo.Update(media); err != nil {
+		return nil, fmt.Errorf("media service: finalize upload: %w", err)
+	}
+	return media, nil
+}
+
+// ReceiveLocalUpload is the receiving end of LocalStore's presigned PUT URL:
+// it verifies the HMAC token PresignedPutURL signed over key+exp and, if
+// valid, writes body straight to disk. Only the local driver has a direct
+// upload endpoint to receive into; other drivers presign against the object
+// storage provider itself.
+func (s *MediaService) ReceiveLocalUpload(ctx context.Context, key string, exp int64, sig string, body io.Reader, size int64, contentType string) error {
+	local, ok := s.store.(*LocalStore)
+	if !ok {
+		return fmt.Errorf("media service: local upload: storage driver %T has no direct upload endpoint", s.store)
+	}
+	if !local.VerifyPutToken(key, exp, sig) {
+		return fmt.Errorf("media service: local upload: invalid or expired signature")
+	}
+	if err := local.Put(ctx, key, body, size, contentType); err != nil {
+		return fmt.Errorf("media service: local upload: %w", err)
+	}
+	return nil
+}
+
+// DeleteUnusedImages removes every media object that is no longer referenced
+// by a post, across whichever backend is configured. ctx is honored so a
+// shutdown in progress aborts the sweep instead of blocking it.
+func (s *MediaService) DeleteUnusedImages(ctx context.Context) error {
+	unused, err := s.repo.ListUnused()
+	if err != nil {
+		return fmt.Errorf("media service: delete unused images: %w", err)
+	}
+
+	for _, m := range unused {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("media service: delete unused images: %w", err)
+		}
+		if err := s.store.Delete(ctx, m.Key); err != nil {
+			s.logger.Error("media service: failed to delete object", zap.String("key", m.Key), zap.Error(err))
+			continue
+		}
+		if err := s.repo.Delete(m.ID); err != nil {
+			s.logger.Error("media service: failed to delete media row", zap.String("key", m.Key), zap.Error(err))
+			continue
+		}
+		s.logger.Info("media service: deleted unused image", zap.String("key", m.Key))
+	}
+	return nil
+}
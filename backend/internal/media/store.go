@@ -0,0 +1,45 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"rag-searchbot-backend/config"
+)
+
+// ObjectStore is the storage abstraction every media driver (local disk, S3,
+// MinIO, Tencent COS, Aliyun OSS) implements. MediaService talks to whichever
+// driver cfg.MediaStorage.Driver selects and never branches on the backend
+// itself.
+type ObjectStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	List(ctx context.Context, prefix string) ([]string, error)
+
+	// PresignedPutURL returns a URL the frontend can PUT the object body to
+	// directly, bypassing the API.
+	PresignedPutURL(ctx context.Context, key string, ttl time.Duration, contentType string) (string, error)
+	// PresignedGetURL returns a time-limited URL to read the object.
+	PresignedGetURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// NewObjectStore builds the ObjectStore selected by cfg.MediaStorage.Driver.
+func NewObjectStore(cfg config.MediaStorageConfig) (ObjectStore, error) {
+	switch cfg.Driver {
+	case "", "local":
+		return NewLocalStore(cfg.Local), nil
+	case "s3":
+		return NewS3Store(cfg.S3)
+	case "minio":
+		return NewMinIOStore(cfg.MinIO)
+	case "cos":
+		return NewCOSStore(cfg.COS)
+	case "oss":
+		return NewOSSStore(cfg.OSS)
+	default:
+		return nil, fmt.Errorf("media: unknown storage driver %q", cfg.Driver)
+	}
+}
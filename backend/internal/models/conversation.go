@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Conversation groups the message history for a single AI chat thread on a
+// post, so ChatStream can load prior turns instead of treating every
+// question as a one-shot prompt.
+type Conversation struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	PostID    uuid.UUID `gorm:"type:uuid;index;not null" json:"post_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;index;not null" json:"user_id"`
+	Title     string    `json:"title"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Messages []Message `gorm:"foreignKey:ConversationID" json:"messages,omitempty"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MessageRole string
+
+const (
+	MessageRoleUser      MessageRole = "user"
+	MessageRoleAssistant MessageRole = "assistant"
+)
+
+// Message is a single turn within a Conversation.
+type Message struct {
+	ID             uuid.UUID   `gorm:"type:uuid;primaryKey" json:"id"`
+	ConversationID uuid.UUID   `gorm:"type:uuid;index;not null" json:"conversation_id"`
+	Role           MessageRole `gorm:"not null" json:"role"`
+	Content        string      `gorm:"type:text;not null" json:"content"`
+	Tokens         int         `json:"tokens"`
+	CreatedAt      time.Time   `json:"created_at"`
+}
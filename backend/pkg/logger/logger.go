@@ -0,0 +1,136 @@
+// Package logger owns the process-wide zap.Logger and a bounded in-memory
+// history of recent entries, so operators can inspect recent activity via
+// api/v1/admin without shelling into the box.
+package logger
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Log is the process-wide logger, set by InitLogger.
+var Log *zap.Logger
+
+// recent backs Recent/the /admin/logs endpoint with a bounded ring of
+// entries, independent of whatever sink InitLogger configures.
+var recent = newRingBuffer(500)
+
+// Entry is one log record as returned by Recent.
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"`
+	Message string    `json:"message"`
+}
+
+// InitLogger builds the process-wide logger for env ("release" or anything
+// else), teeing every entry into the ring buffer that backs Recent.
+func InitLogger(env string) {
+	var cfg zap.Config
+	if env == "release" {
+		cfg = zap.NewProductionConfig()
+	} else {
+		cfg = zap.NewDevelopmentConfig()
+	}
+
+	base, err := cfg.Build()
+	if err != nil {
+		panic(err)
+	}
+
+	Log = base.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &ringCore{Core: core, buf: recent}
+	}))
+}
+
+// ZapLogger is a gin middleware that logs each request through Log.
+func ZapLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		Log.Info("request",
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.Int("status", c.Writer.Status()),
+			zap.Duration("latency", time.Since(start)),
+		)
+	}
+}
+
+// Recent returns the last n log entries captured since startup, oldest
+// first. n <= 0 or n larger than the buffer's contents returns everything
+// buffered.
+func Recent(n int) []Entry {
+	return recent.snapshot(n)
+}
+
+// ringCore tees every entry into an in-memory ring buffer alongside writing
+// it to the wrapped core, so Recent can answer without re-reading whatever
+// file or service the real core writes to.
+type ringCore struct {
+	zapcore.Core
+	buf *ringBuffer
+}
+
+func (c *ringCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+	return ce
+}
+
+func (c *ringCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	c.buf.add(Entry{Time: entry.Time, Level: entry.Level.String(), Message: entry.Message})
+	return c.Core.Write(entry, fields)
+}
+
+func (c *ringCore) With(fields []zapcore.Field) zapcore.Core {
+	return &ringCore{Core: c.Core.With(fields), buf: c.buf}
+}
+
+type ringBuffer struct {
+	mu      sync.Mutex
+	entries []Entry
+	next    int
+	full    bool
+}
+
+func newRingBuffer(capacity int) *ringBuffer {
+	return &ringBuffer{entries: make([]Entry, capacity)}
+}
+
+func (r *ringBuffer) add(e Entry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[r.next] = e
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// snapshot returns up to n of the most recent entries, oldest first.
+func (r *ringBuffer) snapshot(n int) []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	size := r.next
+	if r.full {
+		size = len(r.entries)
+	}
+	if n <= 0 || n > size {
+		n = size
+	}
+
+	out := make([]Entry, 0, n)
+	start := r.next - n
+	for i := 0; i < n; i++ {
+		idx := (start + i + len(r.entries)) % len(r.entries)
+		out = append(out, r.entries[idx])
+	}
+	return out
+}